@@ -102,6 +102,11 @@ func connect() {
 
 	sig := sign.Unblind(ballotKey, response.BlindedBallotSignature, unblinder)
 
+	if !sign.Verify(ballotKey, ballot, sig) {
+		fmt.Println("illegal response from server. Unblinded signature does not verify against ballot:", ballot, sig)
+		panic("invalid unblinded signature")
+	}
+
 	SubmitBallot(ballot, sig)
 }
 
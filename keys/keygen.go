@@ -0,0 +1,109 @@
+package keys
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"io"
+	"math/big"
+)
+
+var bigOne = big.NewInt(1)
+
+const defaultE = 65537
+
+// GenerateSafePrime samples odd, bits-length integers with the top two bits
+// set until it finds one p that is probably prime and for which (p-1)/2 is
+// also probably prime - a safe prime. Safe primes mitigate certain attacks
+// against RSA blind signatures that exploit smooth factors of p-1 and q-1.
+func GenerateSafePrime(random io.Reader, bits int) (*big.Int, error) {
+	if bits < 3 {
+		return nil, errors.New("keys: GenerateSafePrime requires at least 3 bits")
+	}
+
+	byteLen := (bits + 7) / 8
+	buf := make([]byte, byteLen)
+	half := new(big.Int)
+
+	for {
+		if _, err := io.ReadFull(random, buf); err != nil {
+			return nil, err
+		}
+		p := new(big.Int).SetBytes(buf)
+		// buf may have more bits than requested when bits isn't a multiple
+		// of 8 (e.g. bits=17 needs 3 bytes, i.e. 24 bits of entropy); shift
+		// off the excess before fixing the top two and bottom bits, or p
+		// would silently come out longer than bits.
+		p.Rsh(p, uint(byteLen*8-bits))
+		p.SetBit(p, bits-1, 1)
+		p.SetBit(p, bits-2, 1)
+		p.SetBit(p, 0, 1)
+
+		if !p.ProbablyPrime(20) {
+			continue
+		}
+
+		half.Sub(p, bigOne)
+		half.Rsh(half, 1)
+		if half.ProbablyPrime(20) {
+			return p, nil
+		}
+	}
+}
+
+// GenerateElectionKey builds a 2-prime RSA key from two independently
+// generated safe primes, the property blind-signature election keys
+// (ballotKey, voteKey, and per-voter keys) need that rsa.GenerateKey does
+// not provide. The key is validated with key.Validate() before it is
+// returned.
+func GenerateElectionKey(bits int) (*rsa.PrivateKey, error) {
+	primeBits := bits / 2
+
+	p, err := GenerateSafePrime(rand.Reader, primeBits)
+	if err != nil {
+		return nil, err
+	}
+	q, err := GenerateSafePrime(rand.Reader, bits-primeBits)
+	if err != nil {
+		return nil, err
+	}
+	for p.Cmp(q) == 0 {
+		if q, err = GenerateSafePrime(rand.Reader, bits-primeBits); err != nil {
+			return nil, err
+		}
+	}
+
+	key := &rsa.PrivateKey{
+		PublicKey: rsa.PublicKey{
+			N: new(big.Int).Mul(p, q),
+			E: defaultE,
+		},
+		Primes: []*big.Int{p, q},
+	}
+
+	phi := new(big.Int).Mul(
+		new(big.Int).Sub(p, bigOne),
+		new(big.Int).Sub(q, bigOne),
+	)
+	d := new(big.Int).ModInverse(big.NewInt(defaultE), phi)
+	if d == nil {
+		return nil, errors.New("keys: public exponent is not invertible mod (p-1)(q-1)")
+	}
+	key.D = d
+	key.Precompute()
+
+	if err := key.Validate(); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// GenerateMultiPrimeKey mirrors rsa.GenerateMultiPrimeKey, producing an
+// nprimes-prime RSA key whose Precomputed.CRTValues are populated so
+// sign.decrypt takes the multi-prime CRT branch. Unlike GenerateElectionKey
+// it does not require its factors to be safe primes; use it when the
+// deployment wants extra CRT primes for decryption speed rather than the
+// blind-signature hardening safe primes provide.
+func GenerateMultiPrimeKey(nprimes, bits int) (*rsa.PrivateKey, error) {
+	return rsa.GenerateMultiPrimeKey(rand.Reader, nprimes, bits)
+}
@@ -0,0 +1,52 @@
+package keys
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+func TestGenerateSafePrime(t *testing.T) {
+	for _, bits := range []int{128, 17, 20, 67} {
+		p, err := GenerateSafePrime(rand.Reader, bits)
+		if err != nil {
+			t.Fatalf("GenerateSafePrime(%d) failed: %v", bits, err)
+		}
+		if p.BitLen() != bits {
+			t.Fatalf("GenerateSafePrime(%d) returned a %d-bit value", bits, p.BitLen())
+		}
+		if !p.ProbablyPrime(20) {
+			t.Fatalf("GenerateSafePrime(%d) returned a non-prime", bits)
+		}
+		half := new(big.Int).Rsh(new(big.Int).Sub(p, bigOne), 1)
+		if !half.ProbablyPrime(20) {
+			t.Fatalf("GenerateSafePrime(%d) returned p where (p-1)/2 is not prime", bits)
+		}
+	}
+}
+
+func TestGenerateElectionKey(t *testing.T) {
+	key, err := GenerateElectionKey(256)
+	if err != nil {
+		t.Fatalf("GenerateElectionKey failed: %v", err)
+	}
+	if err := key.Validate(); err != nil {
+		t.Fatalf("generated key failed Validate: %v", err)
+	}
+	if key.E != defaultE {
+		t.Fatalf("expected E=%d, got %d", defaultE, key.E)
+	}
+}
+
+func TestGenerateMultiPrimeKey(t *testing.T) {
+	key, err := GenerateMultiPrimeKey(3, 384)
+	if err != nil {
+		t.Fatalf("GenerateMultiPrimeKey failed: %v", err)
+	}
+	if len(key.Primes) != 3 {
+		t.Fatalf("expected 3 primes, got %d", len(key.Primes))
+	}
+	if len(key.Precomputed.CRTValues) != 1 {
+		t.Fatalf("expected CRTValues for the extra prime, got %d entries", len(key.Precomputed.CRTValues))
+	}
+}
@@ -0,0 +1,98 @@
+package sign
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"math/big"
+	mathrand "math/rand"
+	"testing"
+)
+
+// runNoPanic calls fn and turns any panic into a test failure, so a
+// regression shows up as a normal failing assertion rather than a crashed
+// test binary.
+func runNoPanic(t *testing.T, name string, fn func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			t.Errorf("%s panicked on input: %v", name, r)
+		}
+	}()
+	fn()
+}
+
+func randomBytes(r *mathrand.Rand, maxLen int) []byte {
+	b := make([]byte, r.Intn(maxLen+1))
+	r.Read(b)
+	return b
+}
+
+func TestFuzzBlindSign(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 512)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+
+	r := mathrand.New(mathrand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		data := randomBytes(r, 128)
+		runNoPanic(t, "BlindSign", func() {
+			BlindSign(key, data)
+		})
+	}
+}
+
+func TestFuzzCheckBlindSig(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 512)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+
+	r := mathrand.New(mathrand.NewSource(2))
+	for i := 0; i < 1000; i++ {
+		data := randomBytes(r, 128)
+		sig := randomBytes(r, 128)
+		runNoPanic(t, "CheckBlindSig", func() {
+			CheckBlindSig(&key.PublicKey, data, sig)
+		})
+	}
+}
+
+func TestFuzzUnblind(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 512)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+
+	r := mathrand.New(mathrand.NewSource(3))
+	for i := 0; i < 1000; i++ {
+		blindedSig := randomBytes(r, 128)
+		unblinder := randomBytes(r, 128)
+		runNoPanic(t, "Unblind", func() {
+			Unblind(&key.PublicKey, blindedSig, unblinder)
+		})
+	}
+}
+
+func TestBlindSignRejectsOutOfRangeInput(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 512)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+
+	tooBig := make([]byte, key.N.BitLen()/8+16)
+	for i := range tooBig {
+		tooBig[i] = 0xFF
+	}
+	if _, err := BlindSign(key, tooBig); err == nil {
+		t.Fatalf("expected an error for an oversized input, got nil")
+	}
+
+	nEncoded := new(big.Int).Set(key.N).Bytes()
+	if _, err := BlindSign(key, nEncoded); err == nil {
+		t.Fatalf("expected an error for c == N, got nil")
+	}
+
+	if _, err := BlindSign(key, []byte{0}); err == nil {
+		t.Fatalf("expected an error for c == 0, got nil")
+	}
+}
@@ -0,0 +1,29 @@
+package sign
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+)
+
+func TestSignVerifyPSS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+
+	data := []byte("ballot: yes on proposition 1")
+
+	for _, hash := range []crypto.Hash{crypto.SHA256, crypto.SHA512} {
+		opts := &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthAuto, Hash: hash}
+
+		sig, err := SignPSS(key, data, opts)
+		if err != nil {
+			t.Fatalf("SignPSS with %v failed: %v", hash, err)
+		}
+		if !VerifyPSS(&key.PublicKey, data, sig, opts) {
+			t.Fatalf("VerifyPSS rejected a valid %v signature", hash)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	_ "crypto/sha256"
+	"encoding/binary"
 	"io"
 	"math/big"
 )
@@ -17,9 +18,12 @@ func Hash(data []byte) []byte {
 	return h.Sum(make([]byte, 0))
 }
 
-func Sign(key *rsa.PrivateKey, data []byte) (sig []byte, err error) {
+// Sign signs data's hash using signer, which is typically an *rsa.PrivateKey
+// but may be any crypto.Signer - an HSM, KMS, or PKCS#11 handle - so the
+// signing key need not live in process.
+func Sign(signer crypto.Signer, data []byte) (sig []byte, err error) {
 	hashResult := Hash(data)
-	sig, err = rsa.SignPKCS1v15(rand.Reader, key, hashType, hashResult)
+	sig, err = signer.Sign(rand.Reader, hashResult, hashType)
 	return
 }
 
@@ -29,21 +33,98 @@ func CheckSig(key *rsa.PublicKey, data, sig []byte) bool {
 	return err == nil
 }
 
-func BlindSign(key *rsa.PrivateKey, data []byte) []byte {
+// SignPSS is an alternative to Sign that uses RSA-PSS instead of PKCS#1
+// v1.5. Pass nil for opts to use the package's default hashType with
+// rsa.PSSSaltLengthAuto. The message is hashed with opts.Hash (not the
+// fixed hashType), since rsa.SignPSS requires the digest length to match
+// the hash algorithm it's told was used.
+func SignPSS(key *rsa.PrivateKey, data []byte, opts *rsa.PSSOptions) (sig []byte, err error) {
+	if opts == nil {
+		opts = &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthAuto, Hash: hashType}
+	}
+	h := opts.Hash.New()
+	h.Write(data)
+	hashResult := h.Sum(nil)
+	sig, err = rsa.SignPSS(rand.Reader, key, opts.Hash, hashResult, opts)
+	return
+}
+
+// VerifyPSS is the RSA-PSS counterpart to CheckSig. See SignPSS for why the
+// message is hashed with opts.Hash rather than the fixed hashType.
+func VerifyPSS(key *rsa.PublicKey, data, sig []byte, opts *rsa.PSSOptions) bool {
+	if opts == nil {
+		opts = &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthAuto, Hash: hashType}
+	}
+	h := opts.Hash.New()
+	h.Write(data)
+	hashResult := h.Sum(nil)
+	err := rsa.VerifyPSS(key, opts.Hash, hashResult, sig, opts)
+	return err == nil
+}
+
+// BlindSign performs the raw RSA "sign" (decrypt) operation over a blinded
+// ballot digest supplied by a client. data is untrusted: it rejects inputs
+// that are too large to be a valid ciphertext for key, are >= key.N, or are
+// zero, and returns decrypt's error rather than letting it panic, so a
+// malformed SignatureRequest cannot bring down the signing server.
+func BlindSign(key *rsa.PrivateKey, data []byte) ([]byte, error) {
+	if len(data) > (key.N.BitLen()+7)/8 {
+		return nil, rsa.ErrDecryption
+	}
 	c := new(big.Int).SetBytes(data)
+	if c.Sign() == 0 || c.Cmp(key.N) >= 0 {
+		return nil, rsa.ErrDecryption
+	}
 	m, err := decrypt(rand.Reader, key, c)
 	if err != nil {
-		// TODO: handel errors that be caused by bad user input
-		panic(err)
+		return nil, err
 	}
-	return m.Bytes()
+	return m.Bytes(), nil
 }
 
 var bigOne = big.NewInt(1)
 var bigZero = big.NewInt(0)
 
+// FDH computes a full-domain hash of msg as a uniformly distributed integer
+// in [1, key.N-1). For attempt counter i (starting at 0) it expands
+// H(i||0||msg), H(i||1||msg), ... with hashType, the counters varint-encoded,
+// until it has at least key.N.BitLen()+128 bits, reduces the result mod N,
+// and advances i if the result lands on 0 or N-1 (the RSA blind signature
+// scheme is existentially forgeable on those values).
+//
+// This is the standard RSA-FDH construction: it makes Blind/BlindSign
+// operate on a value indistinguishable from random rather than on raw
+// attacker-controlled bytes, which is what makes the raw-RSA blind
+// signature scheme forgeable via RSA's multiplicative property.
+func FDH(key *rsa.PublicKey, msg []byte) *big.Int {
+	need := (key.N.BitLen() + 128 + 7) / 8
+	nMinusOne := new(big.Int).Sub(key.N, bigOne)
+
+	for i := 0; ; i++ {
+		var expansion []byte
+		for j := 0; len(expansion) < need; j++ {
+			h := hashType.New()
+			writeUvarint(h, uint64(i))
+			writeUvarint(h, uint64(j))
+			h.Write(msg)
+			expansion = h.Sum(expansion)
+		}
+		m := new(big.Int).SetBytes(expansion[:need])
+		m.Mod(m, key.N)
+		if m.Sign() != 0 && m.Cmp(nMinusOne) != 0 {
+			return m
+		}
+	}
+}
+
+func writeUvarint(w io.Writer, v uint64) {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], v)
+	w.Write(buf[:n])
+}
+
 func Blind(key *rsa.PublicKey, data []byte) (blindedData, unblinder []byte) {
-	blinded, unblinderBig, err := blind(rand.Reader, key, new(big.Int).SetBytes(data))
+	blinded, unblinderBig, err := blind(rand.Reader, key, FDH(key, data))
 	if err != nil {
 		panic(err)
 	}
@@ -58,6 +139,10 @@ func Unblind(key *rsa.PublicKey, blindedSig, unblinder []byte) []byte {
 	return m.Bytes()
 }
 
+// CheckBlindSig verifies that sig is the signature the authority's key
+// would produce for the already-blinded value data, i.e. that
+// sig^e mod N == data. Use this to confirm a SignatureResponse actually
+// answers the request that was sent, before unblinding it.
 func CheckBlindSig(key *rsa.PublicKey, data, sig []byte) bool {
 	m := new(big.Int).SetBytes(data)
 	bigSig := new(big.Int).SetBytes(sig)
@@ -65,6 +150,17 @@ func CheckBlindSig(key *rsa.PublicKey, data, sig []byte) bool {
 	return m.Cmp(c) == 0
 }
 
+// Verify checks an unblinded blind signature against the original message,
+// recomputing FDH(msg) and comparing sig^e mod N against it. Voters and
+// tally servers should both use this (rather than re-deriving FDH
+// themselves) so they agree on what counts as a valid ballot signature.
+func Verify(key *rsa.PublicKey, msg, sig []byte) bool {
+	m := FDH(key, msg)
+	bigSig := new(big.Int).SetBytes(sig)
+	c := encrypt(new(big.Int), key, bigSig)
+	return m.Cmp(c) == 0
+}
+
 // Taken from crypto/rsa
 func encrypt(c *big.Int, pub *rsa.PublicKey, m *big.Int) *big.Int {
 	e := big.NewInt(int64(pub.E))
@@ -0,0 +1,77 @@
+package sign
+
+import (
+	"crypto/rsa"
+	"crypto/tls"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// maxRemoteSigLen bounds how large a blinded signature RemoteBlindSigner
+// will read from the wire before allocating a buffer for it. No realistic
+// RSA modulus comes anywhere close to this; it exists only so a malformed
+// or compromised response from the signer daemon - or a transport glitch
+// that desyncs the length-prefixed framing - can't force an unbounded
+// allocation in the authority process that's supposed to stay up during an
+// election.
+const maxRemoteSigLen = 1 << 16
+
+// BlindSigner performs the raw RSA "sign" operation used by a blind-signing
+// election authority: it turns a blinded ballot digest into a blinded
+// signature without ever seeing the plaintext ballot. Implementations can
+// keep the underlying private key anywhere the deployment needs it to
+// live - in process, behind an HSM or KMS, or on a separate signer daemon
+// reachable only by the authority.
+type BlindSigner interface {
+	BlindSign(blindedDigest []byte) ([]byte, error)
+}
+
+// LocalBlindSigner is the default BlindSigner: it holds the ballot-signing
+// private key in process and performs the decrypt itself.
+type LocalBlindSigner struct {
+	Key *rsa.PrivateKey
+}
+
+func (s *LocalBlindSigner) BlindSign(blindedDigest []byte) ([]byte, error) {
+	return BlindSign(s.Key, blindedDigest)
+}
+
+// RemoteBlindSigner forwards blind-signing requests to a signer daemon over
+// a mutually authenticated TLS connection, so the ballot-signing private
+// key can live on hardware the election-night server never touches. The
+// wire format is deliberately minimal: a 4-byte big-endian length followed
+// by the blinded digest, and a 4-byte big-endian length followed by the
+// resulting blinded signature.
+type RemoteBlindSigner struct {
+	Addr      string
+	TLSConfig *tls.Config
+}
+
+func (s *RemoteBlindSigner) BlindSign(blindedDigest []byte) ([]byte, error) {
+	conn, err := tls.Dial("tcp", s.Addr, s.TLSConfig)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if err := binary.Write(conn, binary.BigEndian, uint32(len(blindedDigest))); err != nil {
+		return nil, err
+	}
+	if _, err := conn.Write(blindedDigest); err != nil {
+		return nil, err
+	}
+
+	var sigLen uint32
+	if err := binary.Read(conn, binary.BigEndian, &sigLen); err != nil {
+		return nil, err
+	}
+	if sigLen > maxRemoteSigLen {
+		return nil, fmt.Errorf("sign: remote blind signer reported an implausible signature length %d", sigLen)
+	}
+	sig := make([]byte, sigLen)
+	if _, err := io.ReadFull(conn, sig); err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
@@ -0,0 +1,249 @@
+package sign
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/binary"
+	"io"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+)
+
+// mockRemoteBlindSigner stands in for a RemoteBlindSigner talking to a real
+// signer daemon: it holds the private key "on the other side of the wire"
+// and only ever receives the blinded digest, exercising the same BlindSigner
+// contract without requiring a live TLS listener in tests.
+type mockRemoteBlindSigner struct {
+	key *rsa.PrivateKey
+}
+
+func (s *mockRemoteBlindSigner) BlindSign(blindedDigest []byte) ([]byte, error) {
+	return (&LocalBlindSigner{Key: s.key}).BlindSign(blindedDigest)
+}
+
+func testBlindSignerFlow(t *testing.T, signer BlindSigner, key *rsa.PublicKey) {
+	ballot := []byte("ballot: yes on proposition 1")
+
+	blinded, unblinder := Blind(key, ballot)
+
+	blindedSig, err := signer.BlindSign(blinded)
+	if err != nil {
+		t.Fatalf("BlindSign failed: %v", err)
+	}
+
+	if !CheckBlindSig(key, blinded, blindedSig) {
+		t.Fatalf("CheckBlindSig rejected a signature from a valid signer")
+	}
+
+	sig := Unblind(key, blindedSig, unblinder)
+
+	if !Verify(key, ballot, sig) {
+		t.Fatalf("Verify rejected a signature from a valid signer")
+	}
+}
+
+func TestLocalBlindSigner(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+
+	testBlindSignerFlow(t, &LocalBlindSigner{Key: key}, &key.PublicKey)
+}
+
+func TestMockRemoteBlindSigner(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+
+	testBlindSignerFlow(t, &mockRemoteBlindSigner{key: key}, &key.PublicKey)
+}
+
+// generateTestTLSCert builds a throwaway self-signed certificate for a
+// loopback signer daemon listener.
+func generateTestTLSCert(t *testing.T) tls.Certificate {
+	t.Helper()
+
+	certKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		DNSNames:     []string{"localhost"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &certKey.PublicKey, certKey)
+	if err != nil {
+		t.Fatalf("CreateCertificate failed: %v", err)
+	}
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: certKey}
+}
+
+// serveOneBlindSign accepts a single connection, reads the length-prefixed
+// blinded digest, signs it with key, and writes back the length-prefixed
+// blinded signature - the daemon side of RemoteBlindSigner's wire protocol.
+func serveOneBlindSign(t *testing.T, listener net.Listener, key *rsa.PrivateKey) {
+	t.Helper()
+
+	conn, err := listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	var digestLen uint32
+	if err := binary.Read(conn, binary.BigEndian, &digestLen); err != nil {
+		return
+	}
+	digest := make([]byte, digestLen)
+	if _, err := io.ReadFull(conn, digest); err != nil {
+		return
+	}
+
+	sig, err := BlindSign(key, digest)
+	if err != nil {
+		return
+	}
+	if err := binary.Write(conn, binary.BigEndian, uint32(len(sig))); err != nil {
+		return
+	}
+	conn.Write(sig)
+}
+
+// TestRemoteBlindSigner drives a real RemoteBlindSigner over a loopback TLS
+// listener, exercising the actual tls.Dial and length-prefixed
+// binary.Write/binary.Read/io.ReadFull framing rather than a mock.
+func TestRemoteBlindSigner(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{generateTestTLSCert(t)},
+	})
+	if err != nil {
+		t.Fatalf("tls.Listen failed: %v", err)
+	}
+	defer listener.Close()
+
+	go serveOneBlindSign(t, listener, key)
+
+	signer := &RemoteBlindSigner{
+		Addr:      listener.Addr().String(),
+		TLSConfig: &tls.Config{InsecureSkipVerify: true},
+	}
+
+	testBlindSignerFlow(t, signer, &key.PublicKey)
+}
+
+// TestRemoteBlindSignerShortResponse checks that a daemon that hangs up
+// mid-response surfaces as an error rather than a panic or a hang.
+func TestRemoteBlindSignerShortResponse(t *testing.T) {
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{generateTestTLSCert(t)},
+	})
+	if err != nil {
+		t.Fatalf("tls.Listen failed: %v", err)
+	}
+	defer listener.Close()
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var digestLen uint32
+		if err := binary.Read(conn, binary.BigEndian, &digestLen); err != nil {
+			return
+		}
+		digest := make([]byte, digestLen)
+		if _, err := io.ReadFull(conn, digest); err != nil {
+			return
+		}
+
+		// Send only half of the 4-byte length prefix, then hang up.
+		conn.Write([]byte{0, 0})
+	}()
+
+	signer := &RemoteBlindSigner{
+		Addr:      listener.Addr().String(),
+		TLSConfig: &tls.Config{InsecureSkipVerify: true},
+	}
+
+	if _, err := signer.BlindSign([]byte("blinded digest")); err == nil {
+		t.Fatalf("expected an error from a daemon that closes the connection early, got nil")
+	}
+}
+
+// TestRemoteBlindSignerOversizedLength checks that a daemon reporting an
+// implausible signature length is rejected before the client allocates a
+// buffer for it.
+func TestRemoteBlindSignerOversizedLength(t *testing.T) {
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{generateTestTLSCert(t)},
+	})
+	if err != nil {
+		t.Fatalf("tls.Listen failed: %v", err)
+	}
+	defer listener.Close()
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var digestLen uint32
+		if err := binary.Read(conn, binary.BigEndian, &digestLen); err != nil {
+			return
+		}
+		digest := make([]byte, digestLen)
+		if _, err := io.ReadFull(conn, digest); err != nil {
+			return
+		}
+
+		binary.Write(conn, binary.BigEndian, uint32(maxRemoteSigLen+1))
+	}()
+
+	signer := &RemoteBlindSigner{
+		Addr:      listener.Addr().String(),
+		TLSConfig: &tls.Config{InsecureSkipVerify: true},
+	}
+
+	if _, err := signer.BlindSign([]byte("blinded digest")); err == nil {
+		t.Fatalf("expected an error for an implausible signature length, got nil")
+	}
+}
+
+func TestSignWithCryptoSigner(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+
+	data := []byte("I am a voter")
+	sig, err := Sign(key, data)
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	if !CheckSig(&key.PublicKey, data, sig) {
+		t.Fatalf("CheckSig rejected a valid signature")
+	}
+}